@@ -0,0 +1,49 @@
+// Package analyzer implements pactfix's modernization checks as a
+// golang.org/x/tools/go/analysis.Analyzer, so pactfix can run under
+// golangci-lint, nogo, or any other analysis driver in addition to its
+// own standalone checker.
+package analyzer
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const doc = `report Go modernizations pactfix knows how to fix
+
+The analyzer flags interface{} (replaceable by any), io/ioutil call sites
+(replaceable by their os/io equivalents), and fmt.Fprintf/Sprintf/Printf/
+Errorf calls whose format argument isn't a constant string (a
+format-string bug that go vet's printf check also flags), each with a
+SuggestedFix where one can be made safely. With -modernize-http, it also
+flags a top-level main ending in a bare http.ListenAndServe and suggests
+rewriting it to a *http.Server with a graceful shutdown.`
+
+// Analyzer reports pactfix's modernization diagnostics.
+var Analyzer = &analysis.Analyzer{
+	Name: "pactfix",
+	Doc:  doc,
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	for _, file := range pass.Files {
+		interfaceToAny(pass, file)
+		ioutilModernize(pass, file)
+		formatStringSafety(pass, file)
+		httpGracefulShutdown(pass, file)
+	}
+	return nil, nil
+}
+
+// isEmptyInterface reports whether n is the interface{} literal, as
+// opposed to an interface with a non-empty method set such as
+// interface{ M() }, which any is not equivalent to.
+func isEmptyInterface(n ast.Node) (*ast.InterfaceType, bool) {
+	it, ok := n.(*ast.InterfaceType)
+	if !ok || it.Methods == nil || len(it.Methods.List) != 0 {
+		return nil, false
+	}
+	return it, true
+}
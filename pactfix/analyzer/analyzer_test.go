@@ -0,0 +1,22 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/wronai/pactown-debug/pactfix/analyzer"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzer.Analyzer, "a", "b", "c")
+}
+
+func TestHTTPGracefulShutdown(t *testing.T) {
+	if err := analyzer.Analyzer.Flags.Set("modernize-http", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer analyzer.Analyzer.Flags.Set("modernize-http", "false")
+
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), analyzer.Analyzer, "d", "e")
+}
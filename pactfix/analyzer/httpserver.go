@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// modernizeHTTP gates httpGracefulShutdown behind an explicit opt-in: the
+// rewrite is invasive (it replaces the whole last statement of main with
+// a server value, a goroutine, and a signal-driven shutdown), so it
+// shouldn't fire on every run the way the other passes do.
+var modernizeHTTP bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&modernizeHTTP, "modernize-http", false,
+		"rewrite a bare http.ListenAndServe main into a *http.Server with graceful shutdown (opt-in, invasive)")
+}
+
+// httpGracefulShutdown looks for a top-level main whose final statement
+// is an unchecked (or discarded) http.ListenAndServe(addr, handler) call
+// and rewrites it into a *http.Server with a ReadHeaderTimeout (mitigating
+// Slowloris, which go vet/gosec flag) started in a goroutine, shut down
+// gracefully on SIGINT/SIGTERM via signal.NotifyContext.
+func httpGracefulShutdown(pass *analysis.Pass, file *ast.File) {
+	if !modernizeHTTP || pass.TypesInfo == nil {
+		return
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name != "main" || fn.Body == nil || len(fn.Body.List) == 0 {
+			continue
+		}
+		last := fn.Body.List[len(fn.Body.List)-1]
+		call := listenAndServeCall(pass, last)
+		if call == nil || len(call.Args) != 2 {
+			continue
+		}
+
+		addr := exprText(pass.Fset, call.Args[0])
+		handler := exprText(pass.Fset, call.Args[1])
+		newText := fmt.Sprintf(`srv := &http.Server{
+	Addr:              %s,
+	Handler:           %s,
+	ReadHeaderTimeout: 5 * time.Second,
+}
+
+go func() {
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Println("listen:", err)
+	}
+}()
+
+ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+defer stop()
+<-ctx.Done()
+
+shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+defer cancel()
+if err := srv.Shutdown(shutdownCtx); err != nil {
+	fmt.Println("shutdown:", err)
+}`, addr, handler)
+
+		edits := []analysis.TextEdit{{Pos: last.Pos(), End: last.End(), NewText: []byte(newText)}}
+		needs := map[string]bool{"context": true, "errors": true, "fmt": true, "os": true, "os/signal": true, "syscall": true, "time": true}
+		if _, importEdits, ok := ensureImports(pass.Fset, file, "", needs); ok {
+			edits = append(edits, importEdits...)
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "bare http.ListenAndServe has no read-header timeout (Slowloris) and no graceful shutdown",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Rewrite to a *http.Server with graceful shutdown",
+				TextEdits: edits,
+			}},
+		})
+	}
+}
+
+// listenAndServeCall returns the http.ListenAndServe call in stmt, whether
+// it appears bare (its error result discarded by omission) or explicitly
+// discarded with a blank identifier, or nil if stmt doesn't match either
+// shape.
+func listenAndServeCall(pass *analysis.Pass, stmt ast.Stmt) *ast.CallExpr {
+	var call *ast.CallExpr
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		call, _ = s.X.(*ast.CallExpr)
+	case *ast.AssignStmt:
+		if len(s.Rhs) == 1 && allBlank(s.Lhs) {
+			call, _ = s.Rhs[0].(*ast.CallExpr)
+		}
+	}
+	if call == nil {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "ListenAndServe" {
+		return nil
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	pkgName, ok := pass.TypesInfo.Uses[pkgIdent].(*types.PkgName)
+	if !ok || pkgName.Imported().Path() != "net/http" {
+		return nil
+	}
+	return call
+}
+
+func allBlank(exprs []ast.Expr) bool {
+	for _, e := range exprs {
+		id, ok := e.(*ast.Ident)
+		if !ok || id.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
+
+// exprText renders e back to source text, for splicing an existing
+// expression into newly generated statements.
+func exprText(fset *token.FileSet, e ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, e); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// rewriteImportDecl finds the import declaration that imports io/ioutil
+// and returns the edits needed to drop io/ioutil and add each package in
+// needs, unless already present. ok is false if no import declaration in
+// file imports io/ioutil.
+func rewriteImportDecl(fset *token.FileSet, file *ast.File, needs map[string]bool) (decl *ast.GenDecl, edits []analysis.TextEdit, ok bool) {
+	return ensureImports(fset, file, "io/ioutil", needs)
+}
+
+// addImport finds the first import declaration in file and returns the
+// edit needed to add pkg, unless already present. ok is false if pkg is
+// already imported or file has no import declaration.
+func addImport(fset *token.FileSet, file *ast.File, pkg string) (decl *ast.GenDecl, edits []analysis.TextEdit, ok bool) {
+	return ensureImports(fset, file, "", map[string]bool{pkg: true})
+}
+
+// ensureImports finds an import declaration containing removePath (or,
+// if removePath is empty, the first import declaration) and returns the
+// edits needed to drop removePath and add each package in add, unless
+// already present. The edits only touch the specs that actually change:
+// an untouched spec, including any doc or line comment on it and any
+// blank-line grouping around it, is never rewritten. ok is false if
+// there's no matching import declaration or nothing would actually
+// change.
+func ensureImports(fset *token.FileSet, file *ast.File, removePath string, add map[string]bool) (decl *ast.GenDecl, edits []analysis.TextEdit, ok bool) {
+	declImports := func(gd *ast.GenDecl, path string) bool {
+		for _, s := range gd.Specs {
+			if strings.Trim(s.(*ast.ImportSpec).Path.Value, `"`) == path {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, d := range file.Decls {
+		gd, isGenDecl := d.(*ast.GenDecl)
+		if !isGenDecl || gd.Tok != token.IMPORT {
+			continue
+		}
+		if removePath != "" && !declImports(gd, removePath) {
+			continue
+		}
+
+		have := make(map[string]bool, len(file.Imports))
+		for _, is := range file.Imports {
+			have[strings.Trim(is.Path.Value, `"`)] = true
+		}
+		var remove *ast.ImportSpec
+		for _, s := range gd.Specs {
+			is := s.(*ast.ImportSpec)
+			if strings.Trim(is.Path.Value, `"`) == removePath {
+				remove = is
+			}
+		}
+
+		var toAdd []string
+		for pkg := range add {
+			if !have[pkg] {
+				toAdd = append(toAdd, pkg)
+			}
+		}
+		if remove == nil && len(toAdd) == 0 {
+			return nil, nil, false
+		}
+		sort.Strings(toAdd)
+
+		return gd, importEdits(fset, gd, remove, toAdd), true
+	}
+	return nil, nil, false
+}
+
+// importEdits returns the TextEdits that drop remove (if non-nil) from
+// decl and append each package in add (already sorted), disturbing as
+// little of decl's existing text as possible: specs that aren't being
+// removed are never reprinted, so their comments and grouping survive.
+func importEdits(fset *token.FileSet, decl *ast.GenDecl, remove *ast.ImportSpec, add []string) []analysis.TextEdit {
+	if !decl.Lparen.IsValid() {
+		// A bare "import \"path\"" with no parens has exactly one spec.
+		spec := decl.Specs[0].(*ast.ImportSpec)
+		if remove != nil {
+			// The only spec is going away; nothing else in the decl to
+			// preserve, so just replace the whole thing.
+			return []analysis.TextEdit{{Pos: decl.Pos(), End: decl.End(), NewText: []byte(importBlock(add))}}
+		}
+		end := spec.End()
+		if spec.Comment != nil {
+			end = spec.Comment.End()
+		}
+		return []analysis.TextEdit{
+			{Pos: spec.Pos(), End: spec.Pos(), NewText: []byte("(\n\t")},
+			{Pos: end, End: end, NewText: []byte("\n" + importSpecs(add) + ")")},
+		}
+	}
+
+	var edits []analysis.TextEdit
+	if remove != nil {
+		start, stop := specLineRange(fset, remove)
+		edits = append(edits, analysis.TextEdit{Pos: start, End: stop})
+	}
+	if len(add) > 0 {
+		edits = append(edits, analysis.TextEdit{Pos: decl.Rparen, End: decl.Rparen, NewText: []byte(importSpecs(add))})
+	}
+	return edits
+}
+
+// specLineRange returns the byte range of the source lines spanned by
+// spec, including any doc comment above it and line comment beside it,
+// so deleting it doesn't leave orphaned comments or a half-empty line
+// behind.
+func specLineRange(fset *token.FileSet, spec *ast.ImportSpec) (start, end token.Pos) {
+	f := fset.File(spec.Pos())
+
+	startPos := spec.Pos()
+	if spec.Doc != nil {
+		startPos = spec.Doc.Pos()
+	}
+	endPos := spec.End()
+	if spec.Comment != nil {
+		endPos = spec.Comment.End()
+	}
+
+	startLine, endLine := f.Line(startPos), f.Line(endPos)
+	start = f.LineStart(startLine)
+	if endLine < f.LineCount() {
+		end = f.LineStart(endLine + 1)
+	} else {
+		end = token.Pos(f.Base() + f.Size())
+	}
+	return start, end
+}
+
+// importSpecs renders pkgs (already sorted) as one "\tpath\n" line each,
+// for splicing into an existing parenthesized import block.
+func importSpecs(pkgs []string) string {
+	var b strings.Builder
+	for _, pkg := range pkgs {
+		fmt.Fprintf(&b, "\t%q\n", pkg)
+	}
+	return b.String()
+}
+
+// importBlock renders pkgs (already sorted) as a complete import
+// declaration, parenthesized unless there's only one.
+func importBlock(pkgs []string) string {
+	if len(pkgs) == 1 {
+		return fmt.Sprintf("import %q", pkgs[0])
+	}
+	return "import (\n" + importSpecs(pkgs) + ")"
+}
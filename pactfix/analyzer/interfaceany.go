@@ -0,0 +1,32 @@
+package analyzer
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// interfaceToAny reports an empty interface{} occurrence with a
+// SuggestedFix that replaces it with any.
+func interfaceToAny(pass *analysis.Pass, file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		it, ok := isEmptyInterface(n)
+		if !ok {
+			return true
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     it.Pos(),
+			End:     it.End(),
+			Message: "interface{} can be simplified to any",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: "Replace interface{} with any",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     it.Pos(),
+					End:     it.End(),
+					NewText: []byte("any"),
+				}},
+			}},
+		})
+		return true
+	})
+}
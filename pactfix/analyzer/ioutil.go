@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// ioutilRewrites maps an io/ioutil identifier to the package and name that
+// replaces it, per the Go 1.16 io/ioutil deprecation.
+var ioutilRewrites = map[string]struct{ pkg, name string }{
+	"ReadFile":  {"os", "ReadFile"},
+	"WriteFile": {"os", "WriteFile"},
+	"ReadAll":   {"io", "ReadAll"},
+	"ReadDir":   {"os", "ReadDir"},
+	"TempDir":   {"os", "MkdirTemp"},
+	"TempFile":  {"os", "CreateTemp"},
+	"NopCloser": {"io", "NopCloser"},
+	"Discard":   {"io", "Discard"},
+}
+
+// ioutilModernize reports each io/ioutil call site or value with a
+// SuggestedFix that renames it to its os/io replacement, plus one
+// diagnostic on the affected import declaration that updates the import
+// list to match. ioutil.ReadDir becomes os.ReadDir, which returns
+// []os.DirEntry rather than []os.FileInfo; deciding whether a call site
+// needs an Info() wrapper requires following the result through the rest
+// of the function, so this reports a diagnostic instead of guessing.
+func ioutilModernize(pass *analysis.Pass, file *ast.File) {
+	needs := map[string]bool{}
+	found := false
+
+	astutil.Apply(file, func(c *astutil.Cursor) bool {
+		sel, ok := c.Node().(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Name != "ioutil" {
+			return true
+		}
+		rw, ok := ioutilRewrites[sel.Sel.Name]
+		if !ok {
+			return true
+		}
+		found = true
+		needs[rw.pkg] = true
+
+		newText := rw.pkg + "." + rw.name
+		msg := fmt.Sprintf("ioutil.%s can be replaced with %s", sel.Sel.Name, newText)
+		if sel.Sel.Name == "ReadDir" {
+			msg += " (os.ReadDir returns []os.DirEntry, not []os.FileInfo; wrap entries needing .Size()/.Mode() in .Info())"
+		}
+		edits := []analysis.TextEdit{{Pos: sel.Pos(), End: sel.End(), NewText: []byte(newText)}}
+		if call, ok := c.Parent().(*ast.CallExpr); ok && sel.Sel.Name == "WriteFile" && len(call.Args) == 3 {
+			if perm, ok := call.Args[2].(*ast.BasicLit); ok {
+				if newPerm, ok := legacyOctal(perm.Value); ok {
+					edits = append(edits, analysis.TextEdit{Pos: perm.Pos(), End: perm.End(), NewText: []byte(newPerm)})
+				}
+			}
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     sel.Pos(),
+			End:     sel.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Replace with %s", newText),
+				TextEdits: edits,
+			}},
+		})
+		return true
+	}, nil)
+
+	if !found {
+		return
+	}
+	if decl, importEdits, ok := rewriteImportDecl(pass.Fset, file, needs); ok {
+		pass.Report(analysis.Diagnostic{
+			Pos:     decl.Pos(),
+			End:     decl.End(),
+			Message: "imports need updating for the io/ioutil modernization above",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Update imports",
+				TextEdits: importEdits,
+			}},
+		})
+	}
+}
+
+// legacyOctal rewrites a legacy octal literal such as 0664 to the
+// explicit 0o664 form introduced in Go 1.13. It reports ok=false for
+// anything else (hex, binary, already-explicit octal, decimal, float).
+func legacyOctal(value string) (string, bool) {
+	if len(value) < 2 || value[0] != '0' {
+		return "", false
+	}
+	for _, r := range value[1:] {
+		if (r < '0' || r > '7') && r != '_' {
+			return "", false
+		}
+	}
+	return "0o" + value[1:], true
+}
@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fmtNonFormatting maps an fmt function to the non-formatting variant it
+// becomes when its format argument isn't a constant string: passing a
+// caller-controlled string as a format string is the classic format-string
+// bug that go vet's printf check flags.
+var fmtNonFormatting = map[string]string{
+	"Fprintf": "Fprint",
+	"Sprintf": "Sprint",
+	"Printf":  "Print",
+}
+
+// formatStringSafety reports fmt.Fprintf/Sprintf/Printf/Errorf calls
+// whose format argument isn't a constant string literal. Fprintf/Sprintf/
+// Printf calls with no extra variadic args get a SuggestedFix to the
+// non-formatting variant, or to io.WriteString when the writer is an
+// io.Writer and the argument is a string. Errorf has no non-formatting
+// equivalent in fmt, and a non-constant format may still contain a %w
+// wrapping verb we can't see statically, so it's reported without a fix.
+// Calls with extra variadic args are also reported without a fix, since
+// rewriting them would change their meaning.
+func formatStringSafety(pass *analysis.Pass, file *ast.File) {
+	if pass.TypesInfo == nil {
+		return
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		pkgName, ok := pass.TypesInfo.Uses[pkgIdent].(*types.PkgName)
+		if !ok || pkgName.Imported().Path() != "fmt" {
+			return true
+		}
+
+		formatIndex := 0
+		if sel.Sel.Name == "Fprintf" {
+			formatIndex = 1
+		}
+		if _, isTarget := fmtNonFormatting[sel.Sel.Name]; !isTarget && sel.Sel.Name != "Errorf" {
+			return true
+		}
+		if len(call.Args) <= formatIndex || isConstantString(pass.TypesInfo, call.Args[formatIndex]) {
+			return true
+		}
+
+		extraArgs := len(call.Args) > formatIndex+1
+		if sel.Sel.Name == "Errorf" || extraArgs {
+			reason := "fmt." + sel.Sel.Name + "'s format argument is not a constant string literal (a classic format-string bug)"
+			if extraArgs {
+				reason += "; it also has extra arguments, so rewriting it automatically would change its meaning"
+			} else {
+				reason += "; fmt.Errorf has no non-formatting equivalent, and a non-constant format may still contain %w"
+			}
+			pass.Report(analysis.Diagnostic{Pos: call.Pos(), End: call.End(), Message: reason})
+			return true
+		}
+
+		if sel.Sel.Name == "Fprintf" {
+			reportFprintf(pass, file, call, sel)
+			return true
+		}
+
+		nonFormatting := fmtNonFormatting[sel.Sel.Name]
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "fmt." + sel.Sel.Name + "'s format argument is not a constant string literal; use fmt." + nonFormatting + " since there's nothing to format",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Replace with fmt." + nonFormatting,
+				TextEdits: []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(nonFormatting)}},
+			}},
+		})
+		return true
+	})
+}
+
+// reportFprintf reports a single-argument fmt.Fprintf(w, x) call whose
+// format isn't constant. When x is a string and w is an io.Writer, it
+// suggests io.WriteString(w, x); otherwise it suggests fmt.Fprint(w, x).
+func reportFprintf(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, sel *ast.SelectorExpr) {
+	writer, format := call.Args[0], call.Args[1]
+	wt := pass.TypesInfo.TypeOf(writer)
+	ft := pass.TypesInfo.TypeOf(format)
+
+	if wt != nil && ft != nil && types.Identical(ft, types.Typ[types.String]) && types.Implements(wt, ioWriterType()) {
+		edits := []analysis.TextEdit{{
+			Pos:     call.Fun.Pos(),
+			End:     call.Fun.End(),
+			NewText: []byte("io.WriteString"),
+		}}
+		if _, importEdits, ok := addImport(pass.Fset, file, "io"); ok {
+			edits = append(edits, importEdits...)
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "fmt.Fprintf's format argument is not a constant string literal; since w is an io.Writer and the value is a string, use io.WriteString(w, x) instead",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Replace with io.WriteString",
+				TextEdits: edits,
+			}},
+		})
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "fmt.Fprintf's format argument is not a constant string literal; use fmt.Fprint since there's nothing to format",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Replace with fmt.Fprint",
+			TextEdits: []analysis.TextEdit{{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Fprint")}},
+		}},
+	})
+}
+
+func isConstantString(info *types.Info, e ast.Expr) bool {
+	tv, ok := info.Types[e]
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.String
+}
+
+// ioWriterType returns the io.Writer method set, built by hand so this
+// check doesn't depend on the file under analysis actually importing io.
+func ioWriterType() *types.Interface {
+	errType := types.Universe.Lookup("error").Type()
+	params := types.NewTuple(types.NewVar(token.NoPos, nil, "p", types.NewSlice(types.Typ[types.Byte])))
+	results := types.NewTuple(
+		types.NewVar(token.NoPos, nil, "n", types.Typ[types.Int]),
+		types.NewVar(token.NoPos, nil, "err", errType),
+	)
+	sig := types.NewSignatureType(nil, nil, nil, params, results, false)
+	iface := types.NewInterfaceType([]*types.Func{types.NewFunc(token.NoPos, nil, "Write", sig)}, nil)
+	iface.Complete()
+	return iface
+}
@@ -0,0 +1,11 @@
+package a
+
+import "io/ioutil" // want `imports need updating for the io/ioutil modernization above`
+
+func F(x interface{}) string { // want `interface{} can be simplified to any`
+	var y interface{} // want `interface{} can be simplified to any`
+	_ = y
+	b, _ := ioutil.ReadFile("x")       // want `ioutil.ReadFile can be replaced with os.ReadFile`
+	_ = ioutil.WriteFile("x", b, 0664) // want `ioutil.WriteFile can be replaced with os.WriteFile`
+	return string(b)
+}
@@ -0,0 +1,6 @@
+package b
+
+// Reader has a non-empty method set, so it must not be rewritten to any.
+type Reader interface {
+	Read() ([]byte, error)
+}
@@ -0,0 +1,29 @@
+package c
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// handler mirrors the fmt.Fprintf(w, result) pattern pactfix is meant to
+// catch: w is an io.Writer and result is an arbitrary string, so passing
+// it as a format string is a format-string bug.
+func handler(w http.ResponseWriter, result string) {
+	fmt.Fprintf(w, result) // want `fmt.Fprintf's format argument is not a constant string literal`
+}
+
+func build(name string) string {
+	return fmt.Sprintf(name) // want `fmt.Sprintf's format argument is not a constant string literal; use fmt.Sprint`
+}
+
+func wrap(name string, extra string) string {
+	return fmt.Sprintf(name, extra) // want `extra arguments`
+}
+
+func wrapErr(msg string) error {
+	return fmt.Errorf(msg) // want `no non-formatting equivalent`
+}
+
+func ok() string {
+	return fmt.Sprintf("static %d", 1)
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprint(w, "hello")
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+	fmt.Println("Server starting on :8080")
+	http.ListenAndServe(":8080", nil) // want `bare http.ListenAndServe has no read-header timeout`
+}
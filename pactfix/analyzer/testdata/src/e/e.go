@@ -0,0 +1,12 @@
+package main
+
+import "net/http"
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("hello"))
+}
+
+func main() {
+	http.HandleFunc("/", handler)
+	http.ListenAndServe(":8080", nil) // want `bare http.ListenAndServe has no read-header timeout`
+}
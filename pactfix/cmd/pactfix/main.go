@@ -0,0 +1,14 @@
+// Command pactfix is a standalone golang.org/x/tools/go/analysis checker
+// for the pactfix analyzer, usable the same way as any other analysis
+// tool (e.g. `pactfix ./...` or `pactfix -fix ./...`).
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/wronai/pactown-debug/pactfix/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,71 @@
+// Command pactfix applies small, mechanical Go modernization rewrites
+// (interface{} -> any, io/ioutil -> os/io, ...) to the files given on the
+// command line. It parses each file in isolation, so passes that need
+// type information (the fmt.Fprintf format-string check, the
+// http.ListenAndServe modernization) don't run here; use ./cmd/pactfix,
+// which drives the same analyzer through go/packages, for those.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/wronai/pactown-debug/pactfix/rewriter"
+)
+
+func main() {
+	check := flag.Bool("d", false, "display diffs instead of rewriting files; exit non-zero if any file has pending changes")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: pactfix [-d] [files...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	exit := 0
+	for _, path := range files {
+		if *check {
+			exit = checkFile(path, exit)
+			continue
+		}
+		exit = rewriteFile(path, exit)
+	}
+	os.Exit(exit)
+}
+
+func rewriteFile(path string, exit int) int {
+	res, err := rewriter.RewriteFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if res.Changed {
+		fmt.Println(path)
+	}
+	for _, d := range res.Diags {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	return exit
+}
+
+func checkFile(path string, exit int) int {
+	res, diff, err := rewriter.DiffFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if res.Changed {
+		fmt.Print(diff)
+		exit = 1
+	}
+	for _, d := range res.Diags {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	return exit
+}
@@ -0,0 +1,137 @@
+package rewriter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// op is one line of an edit script: a line kept from both inputs, a line
+// only in the old text, or a line only in the new text.
+type op struct {
+	kind rune // ' ', '-', or '+'
+	line string
+}
+
+// unifiedDiff returns a gofmt -d style unified diff between a and b, or
+// "" if they're identical. It emits a single hunk covering everything
+// from the first change to the last (padded with up to context unchanged
+// lines on each side), which keeps the implementation simple and is
+// plenty for the localized, single-function-sized edits pactfix makes.
+func unifiedDiff(path string, a, b []byte, context int) string {
+	ops := diffLines(splitLines(string(a)), splitLines(string(b)))
+
+	first, last := -1, -1
+	for i, o := range ops {
+		if o.kind != ' ' {
+			if first == -1 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first == -1 {
+		return ""
+	}
+	start := first - context
+	if start < 0 {
+		start = 0
+	}
+	end := last + context + 1
+	if end > len(ops) {
+		end = len(ops)
+	}
+	hunk := ops[start:end]
+
+	aStart, bStart := 0, 0
+	for _, o := range ops[:start] {
+		switch o.kind {
+		case ' ':
+			aStart++
+			bStart++
+		case '-':
+			aStart++
+		case '+':
+			bStart++
+		}
+	}
+	aLen, bLen := 0, 0
+	for _, o := range hunk {
+		switch o.kind {
+		case ' ':
+			aLen++
+			bLen++
+		case '-':
+			aLen++
+		case '+':
+			bLen++
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s.orig\n", path)
+	fmt.Fprintf(&out, "+++ %s\n", path)
+	fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", aStart+1, aLen, bStart+1, bLen)
+	for _, o := range hunk {
+		out.WriteRune(o.kind)
+		out.WriteString(o.line)
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a minimal edit script turning a into b via an LCS
+// over lines. Good enough for the file-sized inputs pactfix deals with.
+func diffLines(a, b []string) []op {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
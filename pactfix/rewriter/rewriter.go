@@ -0,0 +1,117 @@
+// Package rewriter is a thin, standalone driver on top of
+// pactfix/analyzer: it runs the analyzer against a single file outside
+// of the full go/analysis package-loading machinery and applies the
+// SuggestedFix edits it reports, for callers that just want to rewrite a
+// file in place without setting up an analysis driver.
+package rewriter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/wronai/pactown-debug/pactfix/analyzer"
+)
+
+// Result describes the outcome of rewriting a single file.
+type Result struct {
+	Path    string
+	Changed bool
+	Diags   []string
+}
+
+// RewriteFile parses path, runs analyzer.Analyzer against it, applies the
+// first SuggestedFix of every reported diagnostic, and writes the result
+// back if anything changed.
+func RewriteFile(path string) (Result, error) {
+	res, src, out, err := rewrite(path)
+	if err != nil || src == nil {
+		return res, err
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		return res, fmt.Errorf("writing %s: %w", path, err)
+	}
+	res.Changed = true
+	return res, nil
+}
+
+// DiffFile parses path and runs analyzer.Analyzer against it like
+// RewriteFile, but instead of writing the result back it returns a
+// unified diff of the change, without touching the file on disk.
+func DiffFile(path string) (Result, string, error) {
+	res, src, out, err := rewrite(path)
+	if err != nil || src == nil {
+		return res, "", err
+	}
+	res.Changed = true
+	return res, unifiedDiff(path, src, out, 3), nil
+}
+
+// rewrite parses path, runs the analyzer, and applies the first
+// SuggestedFix of every reported diagnostic in memory. src and out are
+// both nil if nothing would change.
+func rewrite(path string) (res Result, src, out []byte, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return Result{Path: path}, nil, nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	res = Result{Path: path}
+	var edits []analysis.TextEdit
+	pass := &analysis.Pass{
+		Analyzer: analyzer.Analyzer,
+		Fset:     fset,
+		Files:    []*ast.File{file},
+		Report: func(d analysis.Diagnostic) {
+			res.Diags = append(res.Diags, fmt.Sprintf("%s: %s", fset.Position(d.Pos), d.Message))
+			if len(d.SuggestedFixes) > 0 {
+				edits = append(edits, d.SuggestedFixes[0].TextEdits...)
+			}
+		},
+	}
+	if _, err := analyzer.Analyzer.Run(pass); err != nil {
+		return res, nil, nil, fmt.Errorf("running analyzer on %s: %w", path, err)
+	}
+	if len(edits) == 0 {
+		return res, nil, nil, nil
+	}
+
+	src, err = os.ReadFile(path)
+	if err != nil {
+		return res, nil, nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	out, err = applyEdits(fset, src, edits)
+	if err != nil {
+		return res, nil, nil, fmt.Errorf("applying fixes to %s: %w", path, err)
+	}
+	if formatted, err := format.Source(out); err == nil {
+		out = formatted
+	}
+	return res, src, out, nil
+}
+
+// applyEdits splices non-overlapping edits into src, working back to
+// front so earlier byte offsets stay valid as later edits are applied.
+func applyEdits(fset *token.FileSet, src []byte, edits []analysis.TextEdit) ([]byte, error) {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos > edits[j].Pos })
+
+	out := append([]byte(nil), src...)
+	prevStart := len(out) + 1
+	for _, e := range edits {
+		start := fset.PositionFor(e.Pos, false).Offset
+		end := fset.PositionFor(e.End, false).Offset
+		if end > prevStart {
+			return nil, fmt.Errorf("overlapping suggested fixes at offset %d", start)
+		}
+		out = append(out[:start:start], append(append([]byte{}, e.NewText...), out[end:]...)...)
+		prevStart = start
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package rewriter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRewriteFileAppliesSuggestedFixes(t *testing.T) {
+	src := `package p
+
+import "io/ioutil"
+
+func f(x interface{}) interface{} {
+	b, _ := ioutil.ReadFile("x")
+	return b
+}
+`
+	want := `package p
+
+import "os"
+
+func f(x any) any {
+	b, _ := os.ReadFile("x")
+	return b
+}
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := RewriteFile(path)
+	if err != nil {
+		t.Fatalf("RewriteFile: %v", err)
+	}
+	if !res.Changed {
+		t.Fatalf("expected RewriteFile to report a change")
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRewriteFileNoopOnCleanInput(t *testing.T) {
+	src := `package p
+
+func f(x any) any {
+	return x
+}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := RewriteFile(path)
+	if err != nil {
+		t.Fatalf("RewriteFile: %v", err)
+	}
+	if res.Changed {
+		t.Fatalf("expected no change for already-modern input")
+	}
+}
+
+func TestDiffFileReportsChangeWithoutWriting(t *testing.T) {
+	src := `package p
+
+func f(x interface{}) interface{} {
+	return x
+}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, diff, err := DiffFile(path)
+	if err != nil {
+		t.Fatalf("DiffFile: %v", err)
+	}
+	if !res.Changed {
+		t.Fatalf("expected DiffFile to report a change")
+	}
+	if diff == "" {
+		t.Fatalf("expected a non-empty diff")
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != src {
+		t.Errorf("DiffFile must not modify the file on disk; got:\n%s", got)
+	}
+}
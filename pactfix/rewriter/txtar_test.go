@@ -0,0 +1,76 @@
+package rewriter_test
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/txtar"
+
+	"github.com/wronai/pactown-debug/pactfix/rewriter"
+)
+
+// TestTxtarCorpus runs pactfix over every testdata/txtar/*.txtar archive.
+// Each archive holds an input.go and the want.go it should become, so
+// adding a regression case is a matter of dropping in a new archive
+// rather than writing a Go test function.
+func TestTxtarCorpus(t *testing.T) {
+	archives, err := filepath.Glob("testdata/txtar/*.txtar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) == 0 {
+		t.Fatal("no txtar archives found under testdata/txtar")
+	}
+
+	for _, archivePath := range archives {
+		archivePath := archivePath
+		t.Run(filepath.Base(archivePath), func(t *testing.T) {
+			ar, err := txtar.ParseFile(archivePath)
+			if err != nil {
+				t.Fatalf("parsing archive: %v", err)
+			}
+			input := findFile(t, ar, "input.go")
+			want := findFile(t, ar, "want.go")
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "input.go")
+			if err := os.WriteFile(path, input, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := rewriter.RewriteFile(path); err != nil {
+				t.Fatalf("RewriteFile: %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if normalize(t, got) != normalize(t, want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func findFile(t *testing.T, ar *txtar.Archive, name string) []byte {
+	t.Helper()
+	for _, f := range ar.Files {
+		if f.Name == name {
+			return f.Data
+		}
+	}
+	t.Fatalf("archive has no %s file", name)
+	return nil
+}
+
+func normalize(t *testing.T, src []byte) string {
+	t.Helper()
+	out, err := format.Source(src)
+	if err != nil {
+		t.Fatalf("formatting:\n%s\nerror: %v", src, err)
+	}
+	return string(out)
+}